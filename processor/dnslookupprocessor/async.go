@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+)
+
+// lookupKind distinguishes a forward resolve job from a reverse one on the
+// async queue.
+type lookupKind int
+
+const (
+	lookupResolve lookupKind = iota
+	lookupReverse
+)
+
+type asyncJob struct {
+	kind   lookupKind
+	target string
+}
+
+// asyncPool runs DNS lookups on a bounded pool of background workers instead
+// of inline with record processing. Results aren't written back to the
+// record that triggered them - they land in the resolver's cache (if
+// configured) so that later records for the same target are enriched
+// synchronously on a cache hit. Queue depth and dropped-job counts are
+// exposed via telemetry.
+type asyncPool struct {
+	jobs      chan asyncJob
+	wg        sync.WaitGroup
+	resolver  resolver.Resolver
+	telemetry *telemetryBuilder
+	logger    *zap.Logger
+}
+
+func newAsyncPool(workers, queueSize int, res resolver.Resolver, telemetry *telemetryBuilder, logger *zap.Logger) *asyncPool {
+	p := &asyncPool{
+		jobs:      make(chan asyncJob, queueSize),
+		resolver:  res,
+		telemetry: telemetry,
+		logger:    logger,
+	}
+
+	if err := telemetry.registerAsyncQueueDepth(p.depth); err != nil {
+		logger.Warn("Failed to register dnslookup_async_queue_depth", zap.Error(err))
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.run()
+	}
+
+	return p
+}
+
+// enqueue submits a lookup job without blocking. It returns false, without
+// queuing the job, if the queue is full.
+func (p *asyncPool) enqueue(ctx context.Context, kind lookupKind, target string) bool {
+	select {
+	case p.jobs <- asyncJob{kind: kind, target: target}:
+		return true
+	default:
+		reason := "resolve"
+		if kind == lookupReverse {
+			reason = "reverse"
+		}
+		p.telemetry.recordAsyncDropped(ctx, reason)
+		return false
+	}
+}
+
+func (p *asyncPool) depth() int64 {
+	return int64(len(p.jobs))
+}
+
+func (p *asyncPool) run() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		// The record that enqueued this job may already be gone from the
+		// pipeline by the time the job runs, so there's no caller context to
+		// propagate; a fresh background context is used instead.
+		ctx := context.Background()
+
+		var err error
+		switch job.kind {
+		case lookupResolve:
+			_, err = p.resolver.Resolve(ctx, job.target)
+		case lookupReverse:
+			_, err = p.resolver.Reverse(ctx, job.target)
+		}
+
+		if err != nil && !errors.Is(err, resolver.ErrNoResolution) {
+			p.logger.Debug("Async DNS lookup failed", zap.String("target", job.target), zap.Error(err))
+		}
+	}
+}
+
+// Close stops accepting new jobs and waits for in-flight workers to drain.
+func (p *asyncPool) Close() {
+	close(p.jobs)
+	p.wg.Wait()
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/zap"
+)
+
+type countingResolver struct {
+	resolveCalls atomic.Int32
+}
+
+func (r *countingResolver) Resolve(context.Context, string) (string, error) {
+	r.resolveCalls.Add(1)
+	return "1.2.3.4", nil
+}
+
+func (r *countingResolver) Reverse(context.Context, string) (string, error) {
+	return "example.com", nil
+}
+
+func (r *countingResolver) Name() string { return "counting-mock" }
+func (r *countingResolver) Close() error { return nil }
+
+func newTestTelemetryBuilder(t *testing.T) *telemetryBuilder {
+	t.Helper()
+	tb, err := newTelemetryBuilder(component.TelemetrySettings{
+		Logger:        zap.NewNop(),
+		MeterProvider: noop.NewMeterProvider(),
+	})
+	require.NoError(t, err)
+	return tb
+}
+
+func TestAsyncPool_EnqueueRunsInBackground(t *testing.T) {
+	res := &countingResolver{}
+	pool := newAsyncPool(2, 10, res, newTestTelemetryBuilder(t), zap.NewNop())
+	defer pool.Close()
+
+	ok := pool.enqueue(context.Background(), lookupResolve, "example.com")
+	assert.True(t, ok)
+
+	assert.Eventually(t, func() bool {
+		return res.resolveCalls.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAsyncPool_DropsWhenQueueFull(t *testing.T) {
+	res := &countingResolver{}
+	// No workers: jobs accumulate until the queue is full.
+	pool := &asyncPool{
+		jobs:      make(chan asyncJob, 1),
+		resolver:  res,
+		telemetry: newTestTelemetryBuilder(t),
+		logger:    zap.NewNop(),
+	}
+	defer close(pool.jobs)
+
+	assert.True(t, pool.enqueue(context.Background(), lookupResolve, "a.com"))
+	assert.False(t, pool.enqueue(context.Background(), lookupResolve, "b.com"))
+}
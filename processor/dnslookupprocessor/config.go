@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
+
+import (
+	"fmt"
+	"net/netip"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// ContextID identifies where resolved attributes are written to.
+type ContextID string
+
+const (
+	resource ContextID = "resource"
+	record   ContextID = "record"
+)
+
+// Strategy selects how the resolver chain consults the configured resolvers.
+type Strategy string
+
+const (
+	// StrategyChain consults resolvers one at a time, in configuration order.
+	StrategyChain Strategy = "chain"
+	// StrategyParallelBest fans out to all resolvers concurrently and returns
+	// the first successful, non-ErrNoResolution answer.
+	StrategyParallelBest Strategy = "parallel_best"
+	// StrategyParallelFirst fans out to all resolvers concurrently and returns
+	// whichever resolver answers first, success or not.
+	StrategyParallelFirst Strategy = "parallel_first"
+)
+
+// LookupConfig configures either the forward (resolve) or reverse DNS lookup.
+type LookupConfig struct {
+	Enabled           bool      `mapstructure:"enabled"`
+	Context           ContextID `mapstructure:"context"`
+	Attributes        []string  `mapstructure:"attributes"`
+	ResolvedAttribute string    `mapstructure:"resolved_attribute"`
+
+	// DenyHostnames/AllowHostnames apply to the resolve (forward) lookup:
+	// a hostname matching DenyHostnames is skipped without querying the
+	// resolver chain, unless it also matches AllowHostnames.
+	DenyHostnames  ListConfig `mapstructure:"deny_hostnames"`
+	AllowHostnames ListConfig `mapstructure:"allow_hostnames"`
+
+	// DenyIPs/AllowIPs apply to the reverse lookup, analogous to
+	// DenyHostnames/AllowHostnames.
+	DenyIPs  ListConfig `mapstructure:"deny_ips"`
+	AllowIPs ListConfig `mapstructure:"allow_ips"`
+}
+
+// ListConfig configures one deny/allow list: inline entries, remote/file
+// sources refreshed on RefreshPeriod, or both. Entries are domain suffixes
+// (optionally prefixed with "*.") or IPs/CIDRs, depending on which list they
+// populate.
+type ListConfig struct {
+	Inline        []string      `mapstructure:"inline"`
+	Sources       []string      `mapstructure:"sources"`
+	RefreshPeriod time.Duration `mapstructure:"refresh_period"`
+}
+
+// empty reports whether the list has neither inline entries nor sources.
+func (lc ListConfig) empty() bool {
+	return len(lc.Inline) == 0 && len(lc.Sources) == 0
+}
+
+func (lc *LookupConfig) validate(name string) error {
+	if len(lc.Attributes) == 0 {
+		return fmt.Errorf("%s configuration: at least one attribute must be specified for DNS resolution", name)
+	}
+
+	if lc.ResolvedAttribute == "" {
+		return fmt.Errorf("%s configuration: resovled_attribute must be specified for DNS resolution", name)
+	}
+
+	if lc.Context != resource && lc.Context != record {
+		return fmt.Errorf("%s configuration: context must be either 'resource' or 'record'", name)
+	}
+
+	return nil
+}
+
+// Config defines configuration for the dnslookup processor.
+type Config struct {
+	Resolve LookupConfig `mapstructure:"resolve"`
+	Reverse LookupConfig `mapstructure:"reverse"`
+
+	// Strategy selects how the configured resolvers are consulted: chain (default),
+	// parallel_best, or parallel_first.
+	Strategy Strategy `mapstructure:"strategy"`
+
+	Hostfiles   []string `mapstructure:"hostfiles"`
+	Nameservers []string `mapstructure:"nameservers"`
+
+	// BootstrapNameservers resolve the hostnames of tls:// (DoT) and
+	// https:// (DoH) entries in Nameservers at startup.
+	BootstrapNameservers []string `mapstructure:"bootstrap_nameservers"`
+
+	EnableSystemResolver bool `mapstructure:"enable_system_resolver"`
+
+	MaxRetries int     `mapstructure:"max_retries"`
+	Timeout    float64 `mapstructure:"timeout"`
+
+	HitCacheSize  int `mapstructure:"hit_cache_size"`
+	HitCacheTTL   int `mapstructure:"hit_cache_ttl"`
+	MissCacheSize int `mapstructure:"miss_cache_size"`
+	MissCacheTTL  int `mapstructure:"miss_cache_ttl"`
+
+	// Policies routes lookups for specific domain suffixes or reverse-lookup
+	// CIDRs to a subset of the configured resolvers, instead of the default
+	// chain of every configured resolver.
+	Policies []PolicyConfig `mapstructure:"policies"`
+
+	// ResponseFilter rejects forward-resolution answers that look bogus
+	// (private/bogon IPs) and optionally re-queries FallbackNameservers.
+	ResponseFilter ResponseFilterConfig `mapstructure:"response_filter"`
+
+	// Async, when true, enqueues lookups on a bounded worker pool instead of
+	// resolving inline: the record is emitted immediately without the
+	// resolved attribute, and the answer is cached for subsequent records.
+	Async          bool `mapstructure:"async"`
+	AsyncQueueSize int  `mapstructure:"async_queue_size"`
+	AsyncWorkers   int  `mapstructure:"async_workers"`
+}
+
+// ResponseFilterConfig configures rejection of forward-resolution answers.
+type ResponseFilterConfig struct {
+	PrivateIPRanges     bool     `mapstructure:"private_ip_ranges"`
+	CIDRDeny            []string `mapstructure:"cidr_deny"`
+	CIDRAllow           []string `mapstructure:"cidr_allow"`
+	FallbackNameservers []string `mapstructure:"fallback_nameservers"`
+}
+
+// PolicyConfig routes lookups matching Match to Resolvers instead of the
+// default resolver chain. Match entries are domain suffixes (optionally
+// prefixed with "*.") for hostname routing, or CIDRs/reverse zones (e.g.
+// "10.in-addr.arpa") for reverse-lookup routing. Resolvers names must be one
+// of "hostfiles", "nameservers", or "system_resolver".
+type PolicyConfig struct {
+	Match     []string `mapstructure:"match"`
+	Resolvers []string `mapstructure:"resolvers"`
+}
+
+const (
+	resolverNameHostfiles      = "hostfiles"
+	resolverNameNameservers    = "nameservers"
+	resolverNameSystemResolver = "system_resolver"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if !cfg.Resolve.Enabled && !cfg.Reverse.Enabled {
+		return fmt.Errorf("either forward (resolve) or reverse DNS lookup must be enabled")
+	}
+
+	if cfg.Resolve.Enabled {
+		if err := cfg.Resolve.validate("resolve"); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Reverse.Enabled {
+		if err := cfg.Reverse.validate("reverse"); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+
+	if cfg.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must be non-negative")
+	}
+
+	if cfg.HitCacheSize < 0 {
+		return fmt.Errorf("hit_cache_size must be non-negative")
+	}
+
+	if cfg.MissCacheSize < 0 {
+		return fmt.Errorf("miss_cache_size must be non-negative")
+	}
+
+	if cfg.HitCacheTTL <= 0 {
+		return fmt.Errorf("hit_cache_ttl must be positive")
+	}
+
+	if cfg.MissCacheTTL <= 0 {
+		return fmt.Errorf("miss_cache_ttl must be positive")
+	}
+
+	if !cfg.EnableSystemResolver && len(cfg.Hostfiles) == 0 && len(cfg.Nameservers) == 0 {
+		return fmt.Errorf("at least one of enable_system_resolver, hostfiles, or nameservers must be specified")
+	}
+
+	switch cfg.Strategy {
+	case "", StrategyChain, StrategyParallelBest, StrategyParallelFirst:
+	default:
+		return fmt.Errorf("strategy must be one of 'chain', 'parallel_best', or 'parallel_first'")
+	}
+
+	if cfg.Async {
+		if cfg.AsyncQueueSize <= 0 {
+			return fmt.Errorf("async_queue_size must be positive when async is enabled")
+		}
+		if cfg.AsyncWorkers <= 0 {
+			return fmt.Errorf("async_workers must be positive when async is enabled")
+		}
+		if cfg.HitCacheSize <= 0 && cfg.MissCacheSize <= 0 {
+			return fmt.Errorf("hit_cache_size or miss_cache_size must be positive when async is enabled, otherwise resolved answers are discarded")
+		}
+	}
+
+	for _, cidr := range append(append([]string{}, cfg.ResponseFilter.CIDRDeny...), cfg.ResponseFilter.CIDRAllow...) {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			return fmt.Errorf("response_filter configuration: invalid CIDR %q: %w", cidr, err)
+		}
+	}
+
+	for _, policy := range cfg.Policies {
+		if len(policy.Match) == 0 {
+			return fmt.Errorf("policies configuration: match must not be empty")
+		}
+
+		if len(policy.Resolvers) == 0 {
+			return fmt.Errorf("policies configuration: resolvers must not be empty")
+		}
+
+		for _, name := range policy.Resolvers {
+			switch name {
+			case resolverNameHostfiles, resolverNameNameservers, resolverNameSystemResolver:
+			default:
+				return fmt.Errorf("policies configuration: unknown resolver %q, must be one of 'hostfiles', 'nameservers', or 'system_resolver'", name)
+			}
+		}
+	}
+
+	return nil
+}
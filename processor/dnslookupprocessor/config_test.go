@@ -218,6 +218,123 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Valid strategy chain",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Strategy = StrategyChain
+			},
+			expectError: false,
+		},
+		{
+			name: "Valid strategy parallel_best",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Strategy = StrategyParallelBest
+			},
+			expectError: false,
+		},
+		{
+			name: "Valid strategy parallel_first",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Strategy = StrategyParallelFirst
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid strategy",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Strategy = "bogus"
+			},
+			expectError: true,
+			errorMsg:    "strategy must be one of 'chain', 'parallel_best', or 'parallel_first'",
+		},
+		{
+			name: "Async enabled without queue size",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Async = true
+				cfg.AsyncWorkers = 4
+			},
+			expectError: true,
+			errorMsg:    "async_queue_size must be positive when async is enabled",
+		},
+		{
+			name: "Async enabled without workers",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Async = true
+				cfg.AsyncQueueSize = 100
+			},
+			expectError: true,
+			errorMsg:    "async_workers must be positive when async is enabled",
+		},
+		{
+			name: "Async enabled without any cache configured",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Async = true
+				cfg.AsyncQueueSize = 100
+				cfg.AsyncWorkers = 4
+				cfg.HitCacheSize = 0
+				cfg.MissCacheSize = 0
+			},
+			expectError: true,
+			errorMsg:    "hit_cache_size or miss_cache_size must be positive when async is enabled",
+		},
+		{
+			name: "Async enabled with only hit cache configured",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Async = true
+				cfg.AsyncQueueSize = 100
+				cfg.AsyncWorkers = 4
+				cfg.HitCacheSize = 1000
+				cfg.MissCacheSize = 0
+			},
+			expectError: false,
+		},
+		{
+			name: "Invalid response_filter CIDR",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.ResponseFilter.CIDRDeny = []string{"not-a-cidr"}
+			},
+			expectError: true,
+			errorMsg:    "response_filter configuration: invalid CIDR",
+		},
+		{
+			name: "Valid response_filter CIDRs",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.ResponseFilter.CIDRDeny = []string{"10.0.0.0/8"}
+				cfg.ResponseFilter.CIDRAllow = []string{"10.1.0.0/16"}
+			},
+			expectError: false,
+		},
+		{
+			name: "Policy with empty match",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Policies = []PolicyConfig{{Match: []string{}, Resolvers: []string{resolverNameSystemResolver}}}
+			},
+			expectError: true,
+			errorMsg:    "policies configuration: match must not be empty",
+		},
+		{
+			name: "Policy with empty resolvers",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Policies = []PolicyConfig{{Match: []string{"example.com"}, Resolvers: []string{}}}
+			},
+			expectError: true,
+			errorMsg:    "policies configuration: resolvers must not be empty",
+		},
+		{
+			name: "Policy with unknown resolver",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Policies = []PolicyConfig{{Match: []string{"example.com"}, Resolvers: []string{"bogus"}}}
+			},
+			expectError: true,
+			errorMsg:    "policies configuration: unknown resolver",
+		},
+		{
+			name: "Valid policy",
+			mutateConfigFunc: func(cfg *Config) {
+				cfg.Policies = []PolicyConfig{{Match: []string{"example.com"}, Resolvers: []string{resolverNameSystemResolver}}}
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
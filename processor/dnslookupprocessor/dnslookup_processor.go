@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
 
@@ -22,6 +24,14 @@ type dnsLookupProcessor struct {
 	resolver     resolver.Resolver
 	processPairs []ProcessPair
 	logger       *zap.Logger
+	telemetry    *telemetryBuilder
+
+	denyHostnames  *resolver.List
+	allowHostnames *resolver.List
+	denyIPs        *resolver.List
+	allowIPs       *resolver.List
+
+	async *asyncPool
 }
 
 type ProcessPair struct {
@@ -29,16 +39,41 @@ type ProcessPair struct {
 	ProcessFn func(ctx context.Context, pMap pcommon.Map) error
 }
 
-func newDNSLookupProcessor(config *Config, logger *zap.Logger) (*dnsLookupProcessor, error) {
+func newDNSLookupProcessor(config *Config, set component.TelemetrySettings) (*dnsLookupProcessor, error) {
+	logger := set.Logger
+
 	dnsResolver, err := createResolverChain(config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resolver chain: %w", err)
 	}
 
+	telemetry, err := newTelemetryBuilder(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry builder: %w", err)
+	}
+
 	dp := &dnsLookupProcessor{
-		logger:   logger,
-		config:   config,
-		resolver: dnsResolver,
+		logger:    logger,
+		config:    config,
+		resolver:  dnsResolver,
+		telemetry: telemetry,
+	}
+
+	if dp.denyHostnames, err = createList("deny_hostnames", config.Resolve.DenyHostnames, logger); err != nil {
+		return nil, err
+	}
+	if dp.allowHostnames, err = createList("allow_hostnames", config.Resolve.AllowHostnames, logger); err != nil {
+		return nil, err
+	}
+	if dp.denyIPs, err = createList("deny_ips", config.Reverse.DenyIPs, logger); err != nil {
+		return nil, err
+	}
+	if dp.allowIPs, err = createList("allow_ips", config.Reverse.AllowIPs, logger); err != nil {
+		return nil, err
+	}
+
+	if config.Async {
+		dp.async = newAsyncPool(config.AsyncWorkers, config.AsyncQueueSize, dnsResolver, telemetry, logger)
 	}
 
 	dp.processPairs = dp.createProcessPairs()
@@ -46,6 +81,32 @@ func newDNSLookupProcessor(config *Config, logger *zap.Logger) (*dnsLookupProces
 	return dp, nil
 }
 
+// shutdown stops the async worker pool, if any, and closes the resolver
+// chain.
+func (dp *dnsLookupProcessor) shutdown(context.Context) error {
+	if dp.async != nil {
+		dp.async.Close()
+	}
+	return dp.resolver.Close()
+}
+
+// createList builds a resolver.List for a deny/allow config block, or
+// returns nil if it has no inline entries or sources configured.
+func createList(name string, lc ListConfig, logger *zap.Logger) (*resolver.List, error) {
+	if lc.empty() {
+		return nil, nil
+	}
+
+	list, err := resolver.NewList([]resolver.ListSource{
+		{Name: name, Inline: lc.Inline, Locations: lc.Sources},
+	}, lc.RefreshPeriod, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s list: %w", name, err)
+	}
+
+	return list, nil
+}
+
 // createResolverChain creates a chain of resolvers based on the provided configuration.
 // The resolution order is cache -> chain( hostfile -> nameserver -> system resolver ).
 // Returns either a chain resolver or a cache resolver if cache is enabled.
@@ -53,6 +114,7 @@ func newDNSLookupProcessor(config *Config, logger *zap.Logger) (*dnsLookupProces
 func createResolverChain(config *Config, logger *zap.Logger) (resolver.Resolver, error) {
 	var chainResolver resolver.Resolver
 	var resolvers []resolver.Resolver
+	named := make(map[string]resolver.Resolver, 3)
 
 	if len(config.Hostfiles) > 0 {
 		hostfileResolver, err := resolver.NewHostFileResolver(
@@ -64,11 +126,13 @@ func createResolverChain(config *Config, logger *zap.Logger) (resolver.Resolver,
 		}
 
 		resolvers = append(resolvers, hostfileResolver)
+		named[resolverNameHostfiles] = hostfileResolver
 	}
 
 	if len(config.Nameservers) > 0 {
 		nameserverResolver, err := resolver.NewNameserverResolver(
 			config.Nameservers,
+			config.BootstrapNameservers,
 			time.Duration(config.Timeout*float64(time.Second)),
 			logger,
 		)
@@ -77,6 +141,7 @@ func createResolverChain(config *Config, logger *zap.Logger) (resolver.Resolver,
 		}
 
 		resolvers = append(resolvers, nameserverResolver)
+		named[resolverNameNameservers] = nameserverResolver
 	}
 
 	if config.EnableSystemResolver {
@@ -85,13 +150,34 @@ func createResolverChain(config *Config, logger *zap.Logger) (resolver.Resolver,
 			logger,
 		)
 		resolvers = append(resolvers, systemResolver)
+		named[resolverNameSystemResolver] = systemResolver
 	}
 
 	if len(resolvers) == 0 {
 		return nil, fmt.Errorf("no DNS resolver configuration available: either hostfile, nameserver, or system resolver must be enabled")
 	}
 
-	chainResolver = resolver.NewChainResolver(config.MaxRetries, resolvers, logger)
+	filter, fallback, err := createResponseFilter(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch config.Strategy {
+	case StrategyParallelBest:
+		chainResolver = resolver.NewParallelResolver(resolvers, false, filter, fallback, logger)
+	case StrategyParallelFirst:
+		chainResolver = resolver.NewParallelResolver(resolvers, true, filter, fallback, logger)
+	default:
+		chainResolver = resolver.NewChainResolver(config.MaxRetries, resolvers, filter, fallback, logger)
+	}
+
+	if len(config.Policies) > 0 {
+		policyResolver, err := createPolicyResolver(config.Policies, named, chainResolver, config.MaxRetries, filter, fallback, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create policy resolver: %w", err)
+		}
+		chainResolver = policyResolver
+	}
 
 	if config.HitCacheSize > 0 || config.MissCacheSize > 0 {
 		cacheResolver, err := resolver.NewCacheResolver(
@@ -112,6 +198,77 @@ func createResolverChain(config *Config, logger *zap.Logger) (resolver.Resolver,
 	return chainResolver, nil
 }
 
+// createPolicyResolver builds a PolicyResolver from the configured policies,
+// resolving each policy's resolver names against the named resolvers built
+// by createResolverChain and falling back to defaultResolver on no match.
+// Each policy route is itself a ChainResolver built with the same
+// maxRetries/filter/fallback as the default (non-policy) resolver, so routed
+// lookups get the same retry and response-filtering behavior.
+func createPolicyResolver(policies []PolicyConfig, named map[string]resolver.Resolver, defaultResolver resolver.Resolver, maxRetries int, filter *resolver.ResponseFilter, fallback []resolver.Resolver, logger *zap.Logger) (*resolver.PolicyResolver, error) {
+	routes := make([]resolver.PolicyRoute, 0, len(policies))
+
+	for _, policy := range policies {
+		policyResolvers := make([]resolver.Resolver, 0, len(policy.Resolvers))
+		for _, name := range policy.Resolvers {
+			r, ok := named[name]
+			if !ok {
+				return nil, fmt.Errorf("policy resolver %q is not configured", name)
+			}
+			policyResolvers = append(policyResolvers, r)
+		}
+
+		var suffixes []string
+		var cidrs []netip.Prefix
+		for _, match := range policy.Match {
+			if prefix, err := netip.ParsePrefix(match); err == nil {
+				cidrs = append(cidrs, prefix)
+				continue
+			}
+			suffixes = append(suffixes, match)
+		}
+
+		routes = append(routes, resolver.PolicyRoute{
+			DomainSuffixes: suffixes,
+			CIDRs:          cidrs,
+			Resolver:       resolver.NewChainResolver(maxRetries, policyResolvers, filter, fallback, logger),
+		})
+	}
+
+	return resolver.NewPolicyResolver(routes, defaultResolver, logger), nil
+}
+
+// createResponseFilter builds the ResponseFilter and fallback resolver list
+// described by config.ResponseFilter. Returns nil, nil if no filtering is
+// configured.
+func createResponseFilter(config *Config, logger *zap.Logger) (*resolver.ResponseFilter, []resolver.Resolver, error) {
+	rf := config.ResponseFilter
+
+	if !rf.PrivateIPRanges && len(rf.CIDRDeny) == 0 && len(rf.CIDRAllow) == 0 {
+		return nil, nil, nil
+	}
+
+	filter, err := resolver.NewResponseFilter(rf.PrivateIPRanges, rf.CIDRDeny, rf.CIDRAllow)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create response filter: %w", err)
+	}
+
+	var fallback []resolver.Resolver
+	if len(rf.FallbackNameservers) > 0 {
+		fallbackResolver, err := resolver.NewNameserverResolver(
+			rf.FallbackNameservers,
+			config.BootstrapNameservers,
+			time.Duration(config.Timeout*float64(time.Second)),
+			logger,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create fallback nameserver resolver: %w", err)
+		}
+		fallback = append(fallback, fallbackResolver)
+	}
+
+	return filter, fallback, nil
+}
+
 func (dp *dnsLookupProcessor) createProcessPairs() []ProcessPair {
 	if dp.config.Resolve.Enabled && dp.config.Reverse.Enabled &&
 		(dp.config.Resolve.Context == dp.config.Reverse.Context) {
@@ -161,6 +318,26 @@ func (dp *dnsLookupProcessor) processResolveLookup(ctx context.Context, pMap pco
 		return err
 	}
 
+	if dp.denyHostnames != nil {
+		if list, denied := dp.denyHostnames.Match(hostname); denied {
+			if dp.allowHostnames == nil {
+				dp.telemetry.recordBlockedLookup(ctx, "deny_hostnames", list)
+				return nil
+			}
+			if _, allowed := dp.allowHostnames.Match(hostname); !allowed {
+				dp.telemetry.recordBlockedLookup(ctx, "deny_hostnames", list)
+				return nil
+			}
+		}
+	}
+
+	if dp.async != nil {
+		// The record is emitted without the resolved attribute; the answer
+		// is cached in the background for subsequent records.
+		dp.async.enqueue(ctx, lookupResolve, hostname)
+		return nil
+	}
+
 	// Found a hostname. Try to resolve it
 	ip, err := dp.resolver.Resolve(ctx, hostname)
 	if err == nil {
@@ -187,6 +364,26 @@ func (dp *dnsLookupProcessor) processReverseLookup(ctx context.Context, pMap pco
 		return err
 	}
 
+	if dp.denyIPs != nil {
+		if list, denied := dp.denyIPs.Match(ip); denied {
+			if dp.allowIPs == nil {
+				dp.telemetry.recordBlockedLookup(ctx, "deny_ips", list)
+				return nil
+			}
+			if _, allowed := dp.allowIPs.Match(ip); !allowed {
+				dp.telemetry.recordBlockedLookup(ctx, "deny_ips", list)
+				return nil
+			}
+		}
+	}
+
+	if dp.async != nil {
+		// The record is emitted without the resolved attribute; the answer
+		// is cached in the background for subsequent records.
+		dp.async.enqueue(ctx, lookupReverse, ip)
+		return nil
+	}
+
 	// Found an IP. Try to resolve it
 	hostname, err := dp.resolver.Reverse(ctx, ip)
 	if err == nil {
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ListSource is one named group of block/allow-list entries, loaded from
+// inline config values and/or file/URL locations.
+type ListSource struct {
+	// Name identifies this source for logging and metrics, e.g. the
+	// config key ("deny_hostnames") or a user-provided list name.
+	Name      string
+	Inline    []string
+	Locations []string
+}
+
+// List loads domain and IP/CIDR entries from a set of ListSources, refreshing
+// them on an interval when RefreshPeriod is non-zero. It is used for both the
+// resolve deny/allow hostname lists and the reverse deny/allow IP lists.
+type List struct {
+	mu      sync.RWMutex
+	domains *domainTrie[string]
+	cidrs   *cidrTrie[string]
+
+	sources       []ListSource
+	refreshPeriod time.Duration
+	httpClient    *http.Client
+	logger        *zap.Logger
+
+	// lastLoaded caches the entries most recently fetched successfully for
+	// each location, keyed by location. reload uses it to keep serving a
+	// source's prior entries when a refresh's fetch fails, instead of
+	// silently dropping them. Only ever accessed from reload, which never
+	// runs concurrently with itself, so it needs no separate lock.
+	lastLoaded map[string][]string
+
+	stopCh chan struct{}
+}
+
+// NewList builds a List and performs an initial load. A source location that
+// fails to load logs a warning and is skipped rather than failing the whole
+// load, so a transient fetch error doesn't take a previously-working list
+// offline.
+func NewList(sources []ListSource, refreshPeriod time.Duration, logger *zap.Logger) (*List, error) {
+	l := &List{
+		sources:       sources,
+		refreshPeriod: refreshPeriod,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+		logger:        logger,
+		lastLoaded:    make(map[string][]string),
+		stopCh:        make(chan struct{}),
+	}
+
+	l.reload()
+
+	if refreshPeriod > 0 {
+		go l.refreshLoop()
+	}
+
+	return l, nil
+}
+
+// Match reports whether target (a hostname or an IP) matches any entry in
+// the list, and the name of the source it matched.
+func (l *List) Match(target string) (string, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.domains != nil {
+		if name, ok := l.domains.LongestMatch(target); ok {
+			return name, true
+		}
+	}
+
+	if addr, err := netip.ParseAddr(target); err == nil && l.cidrs != nil {
+		if name, ok := l.cidrs.LongestMatch(addr); ok {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// Close stops the background refresh loop, if any.
+func (l *List) Close() error {
+	close(l.stopCh)
+	return nil
+}
+
+func (l *List) refreshLoop() {
+	ticker := time.NewTicker(l.refreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.reload()
+		case <-l.stopCh:
+			return
+		}
+	}
+}
+
+func (l *List) reload() {
+	domains := newDomainTrie[string]()
+	cidrs := newCIDRTrie[string]()
+
+	for _, src := range l.sources {
+		entries := append([]string{}, src.Inline...)
+
+		for _, location := range src.Locations {
+			loaded, err := l.fetch(location)
+			if err != nil {
+				loaded = l.lastLoaded[location]
+				l.logger.Warn("Failed to refresh list source, keeping previously loaded entries",
+					zap.String("list", src.Name), zap.String("location", location), zap.Int("kept_entries", len(loaded)), zap.Error(err))
+			} else {
+				l.lastLoaded[location] = loaded
+			}
+			entries = append(entries, loaded...)
+		}
+
+		for _, entry := range entries {
+			insertListEntry(domains, cidrs, src.Name, entry)
+		}
+	}
+
+	l.mu.Lock()
+	l.domains = domains
+	l.cidrs = cidrs
+	l.mu.Unlock()
+}
+
+func insertListEntry(domains *domainTrie[string], cidrs *cidrTrie[string], source, entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" || strings.HasPrefix(entry, "#") {
+		return
+	}
+
+	if prefix, err := netip.ParsePrefix(entry); err == nil {
+		cidrs.Insert(prefix, source)
+		return
+	}
+
+	if addr, err := netip.ParseAddr(entry); err == nil {
+		cidrs.Insert(netip.PrefixFrom(addr, addr.BitLen()), source)
+		return
+	}
+
+	domains.Insert(entry, source)
+}
+
+func (l *List) fetch(location string) ([]string, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		resp, err := l.httpClient.Get(location)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, location)
+		}
+
+		return readLines(resp.Body)
+	}
+
+	f, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readLines(f)
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
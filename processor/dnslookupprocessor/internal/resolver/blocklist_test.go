@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestList_Match(t *testing.T) {
+	l, err := NewList([]ListSource{
+		{
+			Name:   "inline",
+			Inline: []string{"*.ads.example.com", "10.0.0.0/8", "1.2.3.4"},
+		},
+	}, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"tracker.ads.example.com", true},
+		{"example.com", false},
+		{"10.1.2.3", true},
+		{"1.2.3.4", true},
+		{"8.8.8.8", false},
+	}
+
+	for _, tt := range tests {
+		_, blocked := l.Match(tt.target)
+		assert.Equal(t, tt.want, blocked, tt.target)
+	}
+}
+
+func TestList_RefreshFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "denylist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("blocked.example.com\n"), 0o600))
+
+	l, err := NewList([]ListSource{
+		{Name: "file", Locations: []string{path}},
+	}, 20*time.Millisecond, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	_, blocked := l.Match("blocked.example.com")
+	assert.True(t, blocked)
+	_, blocked = l.Match("other.example.com")
+	assert.False(t, blocked)
+
+	require.NoError(t, os.WriteFile(path, []byte("other.example.com\n"), 0o600))
+
+	assert.Eventually(t, func() bool {
+		_, blocked := l.Match("other.example.com")
+		return blocked
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestList_RefreshKeepsPriorEntriesOnFetchFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "denylist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("blocked.example.com\n"), 0o600))
+
+	l, err := NewList([]ListSource{
+		{Name: "file", Locations: []string{path}},
+	}, 20*time.Millisecond, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	_, blocked := l.Match("blocked.example.com")
+	require.True(t, blocked)
+
+	require.NoError(t, os.Remove(path))
+
+	// Give the refresh loop a chance to hit the now-missing file; the entry
+	// loaded before the failure must still be enforced.
+	time.Sleep(100 * time.Millisecond)
+	_, blocked = l.Match("blocked.example.com")
+	assert.True(t, blocked)
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// bootstrapResolver resolves the hostnames of DoT/DoH nameserver entries at
+// construction time, using a fixed set of bootstrap nameservers so that the
+// processor doesn't need to resolve its own encrypted nameservers' hostnames
+// through itself.
+type bootstrapResolver struct {
+	client      *dns.Client
+	nameservers []string
+	timeout     time.Duration
+}
+
+// newBootstrapResolver builds a bootstrapResolver. If nameservers is empty,
+// resolveHost only accepts entries that are already literal IP addresses.
+func newBootstrapResolver(nameservers []string, timeout time.Duration) (*bootstrapResolver, error) {
+	return &bootstrapResolver{
+		client:      &dns.Client{Net: "udp", Timeout: timeout},
+		nameservers: nameservers,
+		timeout:     timeout,
+	}, nil
+}
+
+// resolveHost resolves the host part of a "host:port" or "host" string to
+// "ip:port"/"ip", passing literal IPs and empty ports through unchanged.
+func (b *bootstrapResolver) resolveHost(hostport string) (string, error) {
+	host, port, err := splitHostPort(hostport)
+	if err != nil {
+		return "", fmt.Errorf("invalid nameserver address %q: %w", hostport, err)
+	}
+
+	if net.ParseIP(host) != nil {
+		return hostport, nil
+	}
+
+	if len(b.nameservers) == 0 {
+		return "", fmt.Errorf("%q is a hostname but no bootstrap_nameservers are configured to resolve it", host)
+	}
+
+	ip, err := b.resolve(host)
+	if err != nil {
+		return "", err
+	}
+
+	if port == "" {
+		return ip, nil
+	}
+	return net.JoinHostPort(ip, port), nil
+}
+
+func (b *bootstrapResolver) resolve(host string) (string, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(host), dns.TypeA)
+
+	var lastErr error
+	for _, ns := range b.nameservers {
+		resp, _, err := b.client.ExchangeContext(context.Background(), msg, ensurePort(ns, "53"))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if ip := aRecordIP(resp); ip != "" {
+			return ip, nil
+		}
+		lastErr = fmt.Errorf("%w: %s", ErrNoResolution, host)
+	}
+
+	return "", fmt.Errorf("failed to bootstrap-resolve %q: %w", host, lastErr)
+}
+
+// splitHostPort splits "host:port" into its parts, tolerating a bare host
+// with no port.
+func splitHostPort(hostport string) (host, port string, err error) {
+	if !strings.Contains(hostport, ":") {
+		return hostport, "", nil
+	}
+	return net.SplitHostPort(hostport)
+}
+
+func ensurePort(hostport, defaultPort string) string {
+	if _, _, err := net.SplitHostPort(hostport); err == nil {
+		return hostport
+	}
+	return net.JoinHostPort(hostport, defaultPort)
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheResolver wraps a Resolver with a pair of hit/miss LRU caches so that
+// repeated lookups for the same target don't reach the wrapped resolver.
+type CacheResolver struct {
+	name      string
+	resolver  Resolver
+	hitCache  *lru.LRU[string, string]
+	missCache *lru.LRU[string, struct{}]
+	group     singleflight.Group
+	logger    *zap.Logger
+}
+
+// NewCacheResolver creates a CacheResolver in front of the given resolver.
+// hitCacheSize/missCacheSize of 0 disables the corresponding cache.
+func NewCacheResolver(resolver Resolver, hitCacheSize int, hitCacheTTL time.Duration, missCacheSize int, missCacheTTL time.Duration, logger *zap.Logger) (*CacheResolver, error) {
+	if resolver == nil {
+		return nil, fmt.Errorf("resolver must not be nil")
+	}
+
+	c := &CacheResolver{
+		name:     "cache",
+		resolver: resolver,
+		logger:   logger,
+	}
+
+	if hitCacheSize > 0 {
+		c.hitCache = lru.NewLRU[string, string](hitCacheSize, nil, hitCacheTTL)
+	}
+
+	if missCacheSize > 0 {
+		c.missCache = lru.NewLRU[string, struct{}](missCacheSize, nil, missCacheTTL)
+	}
+
+	return c, nil
+}
+
+// Resolve resolves the hostname, consulting the hit/miss caches first.
+func (c *CacheResolver) Resolve(ctx context.Context, hostname string) (string, error) {
+	return c.resolveWithCache(ctx, "resolve", LogKeyHostname, hostname, func(ctx context.Context) (string, error) {
+		return c.resolver.Resolve(ctx, hostname)
+	})
+}
+
+// Reverse resolves the IP, consulting the hit/miss caches first.
+func (c *CacheResolver) Reverse(ctx context.Context, ip string) (string, error) {
+	return c.resolveWithCache(ctx, "reverse", LogKeyIP, ip, func(ctx context.Context) (string, error) {
+		return c.resolver.Reverse(ctx, ip)
+	})
+}
+
+func (c *CacheResolver) Name() string {
+	return c.name
+}
+
+// Close closes the wrapped resolver
+func (c *CacheResolver) Close() error {
+	return c.resolver.Close()
+}
+
+// resolveWithCache looks up target in the hit/miss caches and, on a miss,
+// calls resolverFn. Concurrent lookups for the same (direction, target) are
+// collapsed into a single call via singleflight, so that N callers racing on
+// the same unresolved target only produce one upstream query.
+func (c *CacheResolver) resolveWithCache(ctx context.Context, direction, logKey, target string, resolverFn func(ctx context.Context) (string, error)) (string, error) {
+	if c.hitCache != nil {
+		if result, ok := c.hitCache.Get(target); ok {
+			c.logger.Debug(fmt.Sprintf("DNS lookup from %s (hit cache)", c.name), zap.String(logKey, target))
+			return result, nil
+		}
+	}
+
+	if c.missCache != nil {
+		if _, ok := c.missCache.Get(target); ok {
+			c.logger.Debug(fmt.Sprintf("DNS lookup from %s (miss cache)", c.name), zap.String(logKey, target))
+			return "", nil
+		}
+	}
+
+	key := direction + target
+	v, err, _ := c.group.Do(key, func() (any, error) {
+		result, err := resolverFn(ctx)
+		if err != nil {
+			return "", err
+		}
+		return result, nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	result, _ := v.(string)
+
+	if result == "" {
+		if c.missCache != nil {
+			c.missCache.Add(target, struct{}{})
+		}
+		return "", nil
+	}
+
+	if c.hitCache != nil {
+		c.hitCache.Add(target, result)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// slowMockResolver simulates an upstream resolver that takes some time to
+// answer, so that concurrent callers overlap in time.
+type slowMockResolver struct {
+	calls atomic.Int32
+	delay time.Duration
+}
+
+func (m *slowMockResolver) Resolve(_ context.Context, _ string) (string, error) {
+	m.calls.Add(1)
+	time.Sleep(m.delay)
+	return "1.2.3.4", nil
+}
+
+func (m *slowMockResolver) Reverse(_ context.Context, _ string) (string, error) {
+	m.calls.Add(1)
+	time.Sleep(m.delay)
+	return "example.com", nil
+}
+
+func (m *slowMockResolver) Name() string { return "slow-mock" }
+
+func (m *slowMockResolver) Close() error { return nil }
+
+func TestCacheResolver_Resolve_SingleflightDedup(t *testing.T) {
+	mock := &slowMockResolver{delay: 50 * time.Millisecond}
+	c, err := NewCacheResolver(mock, 1000, time.Minute, 1000, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+
+	const goroutines = 50
+	results := make(chan string, goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			ip, err := c.Resolve(context.Background(), "example.com")
+			assert.NoError(t, err)
+			results <- ip
+		}()
+	}
+
+	for i := 0; i < goroutines; i++ {
+		assert.Equal(t, "1.2.3.4", <-results)
+	}
+
+	assert.Equal(t, int32(1), mock.calls.Load())
+}
+
+func TestCacheResolver_Reverse_SingleflightDedup(t *testing.T) {
+	mock := &slowMockResolver{delay: 50 * time.Millisecond}
+	c, err := NewCacheResolver(mock, 1000, time.Minute, 1000, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+
+	const goroutines = 50
+	results := make(chan string, goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			hostname, err := c.Reverse(context.Background(), "1.2.3.4")
+			assert.NoError(t, err)
+			results <- hostname
+		}()
+	}
+
+	for i := 0; i < goroutines; i++ {
+		assert.Equal(t, "example.com", <-results)
+	}
+
+	assert.Equal(t, int32(1), mock.calls.Load())
+}
@@ -13,33 +13,67 @@ import (
 
 // ChainResolver represents a chain of resolvers that will be run in sequence
 type ChainResolver struct {
-	name      string
-	resolvers []Resolver
-	logger    *zap.Logger
+	name       string
+	maxRetries int
+	resolvers  []Resolver
+	// filter and fallback apply only to forward resolution: an IP that
+	// fails filter is treated as ErrNoResolution from that resolver, and
+	// fallback is consulted only once every configured resolver has been
+	// exhausted or filtered out.
+	filter   *ResponseFilter
+	fallback []Resolver
+	logger   *zap.Logger
 }
 
-func NewChainResolver(resolvers []Resolver, logger *zap.Logger) *ChainResolver {
+// NewChainResolver creates a ChainResolver. filter and fallback may be nil
+// to disable response filtering.
+func NewChainResolver(maxRetries int, resolvers []Resolver, filter *ResponseFilter, fallback []Resolver, logger *zap.Logger) *ChainResolver {
 	return &ChainResolver{
-		name:      "chain",
-		resolvers: resolvers,
-		logger:    logger,
+		name:       "chain",
+		maxRetries: maxRetries,
+		resolvers:  resolvers,
+		filter:     filter,
+		fallback:   fallback,
+		logger:     logger,
 	}
 }
 
-// Resolve runs resolvers in sequence.
+// Resolve runs resolvers in sequence, filtering out denied IP answers and
+// falling back to fallback resolvers if every primary resolver fails or is
+// filtered out.
 // Returns the first successful resolution or an error if all resolvers fail
 func (c *ChainResolver) Resolve(ctx context.Context, hostname string) (string, error) {
-	return c.resolveInSequence(LogKeyHostname, hostname, func(r Resolver) (string, error) {
-		return r.Resolve(ctx, hostname)
-	})
+	resolverFn := func(r Resolver) (string, error) {
+		return c.resolveWithRetries(ctx, r, func(r Resolver) (string, error) {
+			result, err := r.Resolve(ctx, hostname)
+			if err != nil {
+				return "", err
+			}
+			if c.filter != nil && result != "" && !c.filter.Allowed(result) {
+				c.logger.Debug("Filtered DNS response", zap.String(LogKeyHostname, hostname), zap.String(LogKeyIP, result), zap.String("resolver", r.Name()))
+				return "", ErrNoResolution
+			}
+			return result, nil
+		})
+	}
+
+	result, err := c.resolveInSequence(c.resolvers, LogKeyHostname, hostname, resolverFn)
+	if result == "" && len(c.fallback) > 0 {
+		return c.resolveInSequence(c.fallback, LogKeyHostname, hostname, resolverFn)
+	}
+
+	return result, err
 }
 
 // Reverse runs resolvers in sequence.
 // Returns the first successful resolution or an error if all resolvers fail
 func (c *ChainResolver) Reverse(ctx context.Context, ip string) (string, error) {
-	return c.resolveInSequence(LogKeyIP, ip, func(r Resolver) (string, error) {
-		return r.Reverse(ctx, ip)
-	})
+	resolverFn := func(r Resolver) (string, error) {
+		return c.resolveWithRetries(ctx, r, func(r Resolver) (string, error) {
+			return r.Reverse(ctx, ip)
+		})
+	}
+	return c.resolveInSequence(c.resolvers, LogKeyIP, ip, resolverFn)
 }
 
 func (c *ChainResolver) Name() string {
@@ -54,26 +88,65 @@ func (c *ChainResolver) Close() error {
 			errs = append(errs, err)
 		}
 	}
+	for _, r := range c.fallback {
+		if err := r.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return errors.Join(errs...)
 }
 
-// resolveInSequence attempts to resolve the given hostname/IP using the chain of resolvers.
-// It returns the first successful IP/hostname. No resolution is considered a success.
+// resolveWithRetries calls queryFn against r up to maxRetries+1 times,
+// stopping early once the context is done or the error is an
+// ErrNSPermanentFailure.
+func (c *ChainResolver) resolveWithRetries(ctx context.Context, r Resolver, queryFn func(Resolver) (string, error)) (string, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		result, err := queryFn(r)
+		if err == nil || errors.Is(err, ErrNoResolution) {
+			return result, err
+		}
+
+		lastErr = err
+
+		if ctx.Err() != nil || errors.Is(err, ErrNSPermanentFailure) {
+			break
+		}
+	}
+
+	return "", lastErr
+}
+
+// resolveInSequence attempts to resolve the given hostname/IP using the given
+// list of resolvers in order.
+// It returns the first successful IP/hostname. A resolver reporting
+// ErrNoResolution (including one filtered out by the response filter) does
+// not stop the walk: the next resolver in the list is tried, and only once
+// every resolver has replied with either ErrNoResolution or a genuine error
+// is there no resolution to return.
 // It returns the last error of the last resolver if all retries failed.
-func (c *ChainResolver) resolveInSequence(logKey string, target string, resolverFn func(resolver Resolver) (string, error)) (string, error) {
+func (c *ChainResolver) resolveInSequence(resolvers []Resolver, logKey string, target string, resolverFn func(resolver Resolver) (string, error)) (string, error) {
 	var lastErr error
+	noResolution := false
 
-	for _, r := range c.resolvers {
+	for _, r := range resolvers {
 		result, err := resolverFn(r)
 
 		// Successful resolution
-		if err == nil || errors.Is(err, ErrNoResolution) {
+		if err == nil {
 			c.logger.Debug(fmt.Sprintf("DNS lookup from %s", r.Name()),
 				zap.String(logKey, target),
 				zap.String(Flip(logKey), result))
 			return result, nil
 		}
 
+		if errors.Is(err, ErrNoResolution) {
+			c.logger.Debug(fmt.Sprintf("DNS lookup from %s", r.Name()), zap.String(logKey, target))
+			noResolution = true
+			continue
+		}
+
 		lastErr = err
 	}
 
@@ -83,5 +156,9 @@ func (c *ChainResolver) resolveInSequence(logKey string, target string, resolver
 		return "", nil
 	}
 
+	if lastErr == nil && noResolution {
+		return "", nil
+	}
+
 	return "", lastErr
 }
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChainResolver_Resolve_FiltersAndFallsBack(t *testing.T) {
+	primary := &namedMockResolver{name: "primary", resolveResult: "192.168.1.1"}
+	fallback := &namedMockResolver{name: "fallback", resolveResult: "8.8.8.8"}
+
+	filter, err := NewResponseFilter(true, nil, nil)
+	require.NoError(t, err)
+
+	c := NewChainResolver(0, []Resolver{primary}, filter, []Resolver{fallback}, zap.NewNop())
+
+	ip, err := c.Resolve(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "8.8.8.8", ip)
+}
+
+func TestChainResolver_Resolve_FilteredAnswerContinuesToNextResolver(t *testing.T) {
+	filtered := &namedMockResolver{name: "filtered", resolveResult: "192.168.1.1"}
+	public := &namedMockResolver{name: "public", resolveResult: "8.8.4.4"}
+	fallback := &namedMockResolver{name: "fallback", resolveResult: "8.8.8.8"}
+
+	filter, err := NewResponseFilter(true, nil, nil)
+	require.NoError(t, err)
+
+	c := NewChainResolver(0, []Resolver{filtered, public}, filter, []Resolver{fallback}, zap.NewNop())
+
+	ip, err := c.Resolve(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "8.8.4.4", ip)
+}
+
+func TestChainResolver_Resolve_NoFilterConfigured(t *testing.T) {
+	primary := &namedMockResolver{name: "primary", resolveResult: "192.168.1.1"}
+
+	c := NewChainResolver(0, []Resolver{primary}, nil, nil, zap.NewNop())
+
+	ip, err := c.Resolve(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.1.1", ip)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// privateIPPrefixes are the RFC1918, ULA, loopback, and link-local ranges
+// rejected when ResponseFilter is configured with PrivateIPRanges.
+var privateIPPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("fc00::/7"),  // ULA
+	netip.MustParsePrefix("::1/128"),   // loopback
+	netip.MustParsePrefix("fe80::/10"), // link-local
+}
+
+// ResponseFilter decides whether a forward-resolution answer is acceptable,
+// so that bogon or private-IP answers from a misconfigured or captive-portal
+// nameserver don't get written into resource attributes.
+type ResponseFilter struct {
+	privateIPRanges bool
+	deny            *cidrTrie[struct{}]
+	allow           *cidrTrie[struct{}]
+}
+
+// NewResponseFilter builds a ResponseFilter. An IP is rejected when it
+// matches privateIPRanges (if enabled) or cidrDeny, unless it also matches
+// cidrAllow, which always takes precedence.
+func NewResponseFilter(privateIPRanges bool, cidrDeny, cidrAllow []string) (*ResponseFilter, error) {
+	f := &ResponseFilter{
+		privateIPRanges: privateIPRanges,
+		deny:            newCIDRTrie[struct{}](),
+		allow:           newCIDRTrie[struct{}](),
+	}
+
+	for _, cidr := range cidrDeny {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr_deny entry %q: %w", cidr, err)
+		}
+		f.deny.Insert(prefix, struct{}{})
+	}
+
+	for _, cidr := range cidrAllow {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr_allow entry %q: %w", cidr, err)
+		}
+		f.allow.Insert(prefix, struct{}{})
+	}
+
+	return f, nil
+}
+
+// Allowed reports whether ip is an acceptable forward-resolution answer.
+func (f *ResponseFilter) Allowed(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		// Not a parseable IP; let the caller's own validation handle it.
+		return true
+	}
+
+	if _, ok := f.allow.LongestMatch(addr); ok {
+		return true
+	}
+
+	if _, ok := f.deny.LongestMatch(addr); ok {
+		return false
+	}
+
+	if f.privateIPRanges {
+		for _, prefix := range privateIPPrefixes {
+			if prefix.Contains(addr) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
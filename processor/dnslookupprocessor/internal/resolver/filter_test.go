@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseFilter_Allowed(t *testing.T) {
+	f, err := NewResponseFilter(true, []string{"203.0.113.0/24"}, []string{"10.1.0.0/16"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		ip   string
+		want bool
+	}{
+		{name: "public IP is allowed", ip: "8.8.8.8", want: true},
+		{name: "private range rejected", ip: "192.168.1.1", want: false},
+		{name: "cidr_deny rejected", ip: "203.0.113.5", want: false},
+		{name: "cidr_allow overrides private range", ip: "10.1.2.3", want: true},
+		{name: "loopback rejected", ip: "127.0.0.1", want: false},
+		{name: "unparseable answer passes through", ip: "not-an-ip", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, f.Allowed(tt.ip))
+		})
+	}
+}
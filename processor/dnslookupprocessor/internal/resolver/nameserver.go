@@ -0,0 +1,287 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+const dohContentType = "application/dns-message"
+
+// nameserverClient performs a single DNS query against one configured
+// nameserver entry, regardless of transport (UDP, TCP, DoT, or DoH).
+type nameserverClient interface {
+	query(ctx context.Context, msg *dns.Msg) (*dns.Msg, error)
+	address() string
+}
+
+// NameserverResolver resolves hostnames/IPs against a configured list of
+// nameservers. Each entry may be a bare UDP address (`1.1.1.1:53`) or a URL
+// specifying the transport: `udp://`, `tcp://`, `tls://` (DoT), or
+// `https://` (DoH).
+type NameserverResolver struct {
+	name    string
+	clients []nameserverClient
+	logger  *zap.Logger
+}
+
+// NewNameserverResolver builds a NameserverResolver from the given nameserver
+// entries. DoT/DoH entries that reference a hostname (rather than a literal
+// IP) are resolved at construction time using bootstrapNameservers, so the
+// resolver doesn't depend on the very DNS resolution it is configured to
+// provide.
+func NewNameserverResolver(nameservers []string, bootstrapNameservers []string, timeout time.Duration, logger *zap.Logger) (*NameserverResolver, error) {
+	if len(nameservers) == 0 {
+		return nil, fmt.Errorf("at least one nameserver must be specified")
+	}
+
+	bootstrap, err := newBootstrapResolver(bootstrapNameservers, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bootstrap resolver: %w", err)
+	}
+
+	// DoT and DoH entries each get their own client: a DoT entry's
+	// TLSConfig.ServerName and a DoH entry's dial override are both specific
+	// to that entry's hostname and must not be shared across entries.
+	clients := make([]nameserverClient, 0, len(nameservers))
+	for _, entry := range nameservers {
+		client, err := newNameserverClient(entry, bootstrap, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure nameserver %q: %w", entry, err)
+		}
+		clients = append(clients, client)
+	}
+
+	return &NameserverResolver{
+		name:    "nameserver",
+		clients: clients,
+		logger:  logger,
+	}, nil
+}
+
+func (n *NameserverResolver) Resolve(ctx context.Context, hostname string) (string, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(hostname), dns.TypeA)
+	return n.query(ctx, LogKeyHostname, hostname, msg, aRecordIP)
+}
+
+func (n *NameserverResolver) Reverse(ctx context.Context, ip string) (string, error) {
+	reverseName, err := dns.ReverseAddr(ip)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidIP, ip)
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(reverseName, dns.TypePTR)
+	return n.query(ctx, LogKeyIP, ip, msg, ptrRecordHostname)
+}
+
+func (n *NameserverResolver) Name() string {
+	return n.name
+}
+
+func (n *NameserverResolver) Close() error {
+	return nil
+}
+
+// query tries each configured nameserver in turn, returning the first answer
+// received; only a query error or a SERVFAIL/REFUSED reply moves on to the
+// next nameserver. A NXDOMAIN/NOERROR-with-no-records response is treated
+// as ErrNoResolution rather than an error, so the caller's chain/parallel
+// resolver can decide whether to fall through to another resolver.
+func (n *NameserverResolver) query(ctx context.Context, logKey, target string, msg *dns.Msg, extract func(*dns.Msg) string) (string, error) {
+	var errs []error
+
+	for _, client := range n.clients {
+		resp, err := client.query(ctx, msg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", client.address(), err))
+			continue
+		}
+
+		if resp.Rcode == dns.RcodeServerFailure || resp.Rcode == dns.RcodeRefused {
+			errs = append(errs, fmt.Errorf("%s: %w: rcode %s", client.address(), ErrNSPermanentFailure, dns.RcodeToString[resp.Rcode]))
+			continue
+		}
+
+		result := extract(resp)
+		if result == "" {
+			n.logger.Debug(fmt.Sprintf("DNS lookup from %s", client.address()), zap.String(logKey, target))
+			return "", ErrNoResolution
+		}
+
+		n.logger.Debug(fmt.Sprintf("DNS lookup from %s", client.address()),
+			zap.String(logKey, target),
+			zap.String(Flip(logKey), result))
+		return result, nil
+	}
+
+	return "", errors.Join(errs...)
+}
+
+func aRecordIP(msg *dns.Msg) string {
+	for _, rr := range msg.Answer {
+		if a, ok := rr.(*dns.A); ok {
+			return a.A.String()
+		}
+	}
+	return ""
+}
+
+func ptrRecordHostname(msg *dns.Msg) string {
+	for _, rr := range msg.Answer {
+		if ptr, ok := rr.(*dns.PTR); ok {
+			return strings.TrimSuffix(ptr.Ptr, ".")
+		}
+	}
+	return ""
+}
+
+// newNameserverClient parses a single nameserver config entry and builds the
+// client for its transport.
+func newNameserverClient(entry string, bootstrap *bootstrapResolver, timeout time.Duration) (nameserverClient, error) {
+	if !strings.Contains(entry, "://") {
+		// Bare "host:port" entries keep their historical plain-UDP behavior.
+		return &dnsNameserverClient{
+			client: &dns.Client{Net: "udp", Timeout: timeout},
+			addr:   entry,
+		}, nil
+	}
+
+	u, err := url.Parse(entry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nameserver URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "udp":
+		return &dnsNameserverClient{
+			client: &dns.Client{Net: "udp", Timeout: timeout},
+			addr:   u.Host,
+		}, nil
+	case "tcp":
+		return &dnsNameserverClient{
+			client: &dns.Client{Net: "tcp", Timeout: timeout},
+			addr:   u.Host,
+		}, nil
+	case "tls":
+		host, err := bootstrap.resolveHost(u.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &dnsNameserverClient{
+			client: &dns.Client{
+				Net:       "tcp-tls",
+				Timeout:   timeout,
+				TLSConfig: &tls.Config{ServerName: u.Hostname()},
+			},
+			addr: host,
+		}, nil
+	case "https":
+		resolved, err := bootstrap.resolveHost(u.Host)
+		if err != nil {
+			return nil, err
+		}
+		// The request URL keeps the original hostname, so the TLS handshake
+		// verifies the certificate against it and the Host header stays
+		// correct; only the actual TCP dial is redirected to the
+		// bootstrap-resolved IP, via a transport dedicated to this entry.
+		resolvedHost, _, splitErr := net.SplitHostPort(resolved)
+		if splitErr != nil {
+			resolvedHost = resolved
+		}
+		dialer := &net.Dialer{Timeout: timeout}
+		transport := &http.Transport{
+			MaxIdleConnsPerHost: 8,
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				_, port, err := net.SplitHostPort(addr)
+				if err != nil {
+					port = "443"
+				}
+				return dialer.DialContext(ctx, network, net.JoinHostPort(resolvedHost, port))
+			},
+		}
+		return &dohNameserverClient{
+			client:  &http.Client{Timeout: timeout, Transport: transport},
+			url:     u.String(),
+			display: u.Host,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported nameserver scheme %q", u.Scheme)
+	}
+}
+
+// dnsNameserverClient handles plain UDP/TCP and DoT (tcp-tls) transports via
+// miekg/dns. For DoT, client.TLSConfig is set once at construction and never
+// mutated, since client may be shared across concurrent queries.
+type dnsNameserverClient struct {
+	client *dns.Client
+	addr   string
+}
+
+func (c *dnsNameserverClient) query(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	resp, _, err := c.client.ExchangeContext(ctx, msg, c.addr)
+	return resp, err
+}
+
+func (c *dnsNameserverClient) address() string { return c.addr }
+
+// dohNameserverClient performs DNS-over-HTTPS: the DNS message is POSTed as
+// application/dns-message and the answer decoded from the response body.
+type dohNameserverClient struct {
+	client  *http.Client
+	url     string
+	display string
+}
+
+func (c *dohNameserverClient) query(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack DNS message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH request to %s failed with status %d", c.display, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := new(dns.Msg)
+	if err := reply.Unpack(body); err != nil {
+		return nil, fmt.Errorf("failed to unpack DoH response: %w", err)
+	}
+
+	return reply, nil
+}
+
+func (c *dohNameserverClient) address() string { return c.display }
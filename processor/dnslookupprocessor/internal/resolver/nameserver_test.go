@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNameserverClient(t *testing.T) {
+	bootstrap, err := newBootstrapResolver(nil, time.Second)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		entry       string
+		wantType    string
+		expectError bool
+	}{
+		{name: "bare address defaults to udp", entry: "8.8.8.8:53", wantType: "*resolver.dnsNameserverClient"},
+		{name: "explicit udp", entry: "udp://8.8.8.8:53", wantType: "*resolver.dnsNameserverClient"},
+		{name: "explicit tcp", entry: "tcp://8.8.8.8:53", wantType: "*resolver.dnsNameserverClient"},
+		{name: "tls with literal IP", entry: "tls://1.1.1.1:853", wantType: "*resolver.dnsNameserverClient"},
+		{name: "https with literal IP", entry: "https://1.1.1.1/dns-query", wantType: "*resolver.dohNameserverClient"},
+		{name: "tls with hostname and no bootstrap fails", entry: "tls://dns.example.com:853", expectError: true},
+		{name: "unsupported scheme", entry: "quic://1.1.1.1:853", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newNameserverClient(tt.entry, bootstrap, time.Second)
+
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			switch tt.wantType {
+			case "*resolver.dnsNameserverClient":
+				_, ok := client.(*dnsNameserverClient)
+				assert.True(t, ok)
+			case "*resolver.dohNameserverClient":
+				_, ok := client.(*dohNameserverClient)
+				assert.True(t, ok)
+			}
+		})
+	}
+}
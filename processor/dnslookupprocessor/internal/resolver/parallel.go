@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ParallelResolver fans out a lookup to all configured resolvers concurrently
+// instead of walking them in sequence. It is a drop-in replacement for
+// ChainResolver when tail latency from a single slow resolver matters more
+// than querying resolvers in a fixed priority order.
+type ParallelResolver struct {
+	name       string
+	resolvers  []Resolver
+	firstReply bool
+	// filter and fallback apply only to forward resolution: an IP that
+	// fails filter is treated as ErrNoResolution from that resolver, and
+	// fallback is consulted only once every configured resolver has been
+	// exhausted or filtered out. See ChainResolver for the sequential
+	// equivalent.
+	filter   *ResponseFilter
+	fallback []Resolver
+	logger   *zap.Logger
+}
+
+// NewParallelResolver creates a ParallelResolver. When firstReply is true
+// (strategy "parallel_first"), the resolver returns whichever resolver
+// answers first, success or failure. When false (strategy "parallel_best"),
+// it waits for the first successful, non-ErrNoResolution answer and only
+// gives up once every resolver has failed. filter and fallback may be nil
+// to disable response filtering.
+func NewParallelResolver(resolvers []Resolver, firstReply bool, filter *ResponseFilter, fallback []Resolver, logger *zap.Logger) *ParallelResolver {
+	return &ParallelResolver{
+		name:       "parallel",
+		resolvers:  resolvers,
+		firstReply: firstReply,
+		filter:     filter,
+		fallback:   fallback,
+		logger:     logger,
+	}
+}
+
+// Resolve fans out to all resolvers concurrently, filtering out denied IP
+// answers and falling back to fallback resolvers if every primary resolver
+// fails or is filtered out.
+func (p *ParallelResolver) Resolve(ctx context.Context, hostname string) (string, error) {
+	resolverFn := func(ctx context.Context, r Resolver) (string, error) {
+		result, err := r.Resolve(ctx, hostname)
+		if err != nil {
+			return "", err
+		}
+		if p.filter != nil && result != "" && !p.filter.Allowed(result) {
+			p.logger.Debug("Filtered DNS response", zap.String(LogKeyHostname, hostname), zap.String(LogKeyIP, result), zap.String("resolver", r.Name()))
+			return "", ErrNoResolution
+		}
+		return result, nil
+	}
+
+	result, err := p.resolveInParallel(ctx, p.resolvers, LogKeyHostname, hostname, resolverFn)
+	if result == "" && len(p.fallback) > 0 {
+		return p.resolveInParallel(ctx, p.fallback, LogKeyHostname, hostname, resolverFn)
+	}
+
+	return result, err
+}
+
+// Reverse fans out to all resolvers concurrently. Unlike Resolve, it does not
+// apply filter or fallback, which only apply to forward resolution.
+func (p *ParallelResolver) Reverse(ctx context.Context, ip string) (string, error) {
+	return p.resolveInParallel(ctx, p.resolvers, LogKeyIP, ip, func(ctx context.Context, r Resolver) (string, error) {
+		return r.Reverse(ctx, ip)
+	})
+}
+
+func (p *ParallelResolver) Name() string {
+	return p.name
+}
+
+// Close closes all resolvers in the pool
+func (p *ParallelResolver) Close() error {
+	var errs []error
+	for _, r := range p.resolvers {
+		if err := r.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, r := range p.fallback {
+		if err := r.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type parallelResult struct {
+	resolver Resolver
+	result   string
+	err      error
+}
+
+// resolveInParallel queries every resolver in resolvers concurrently and
+// cancels the remaining in-flight queries once a winning answer has been
+// chosen.
+func (p *ParallelResolver) resolveInParallel(ctx context.Context, resolvers []Resolver, logKey, target string, resolverFn func(context.Context, Resolver) (string, error)) (string, error) {
+	queryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan parallelResult, len(resolvers))
+
+	var wg sync.WaitGroup
+	for _, r := range resolvers {
+		wg.Add(1)
+		go func(r Resolver) {
+			defer wg.Done()
+			result, err := resolverFn(queryCtx, r)
+			results <- parallelResult{resolver: r, result: result, err: err}
+		}(r)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var errs []error
+	noResolution := false
+	for res := range results {
+		if p.firstReply {
+			cancel()
+			if res.err != nil && !errors.Is(res.err, ErrNoResolution) {
+				return "", res.err
+			}
+			p.logger.Debug(fmt.Sprintf("DNS lookup from %s", res.resolver.Name()),
+				zap.String(logKey, target),
+				zap.String(Flip(logKey), res.result))
+			return res.result, nil
+		}
+
+		// A genuine success wins immediately and cancels the rest. An
+		// ErrNoResolution reply is not a failure, but it isn't a winning
+		// answer either: a slower resolver may still have a real record, so
+		// keep waiting for the remaining resolvers instead of giving up.
+		if res.err == nil {
+			cancel()
+			p.logger.Debug(fmt.Sprintf("DNS lookup from %s", res.resolver.Name()),
+				zap.String(logKey, target),
+				zap.String(Flip(logKey), res.result))
+			return res.result, nil
+		}
+
+		if errors.Is(res.err, ErrNoResolution) {
+			noResolution = true
+			continue
+		}
+
+		errs = append(errs, res.err)
+	}
+
+	if noResolution && len(errs) == 0 {
+		return "", nil
+	}
+
+	return "", errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// timedMockResolver returns a fixed result/error after an optional delay, so
+// tests can control which resolver in a ParallelResolver answers first.
+type timedMockResolver struct {
+	name   string
+	delay  time.Duration
+	result string
+	err    error
+}
+
+func (m *timedMockResolver) Resolve(ctx context.Context, _ string) (string, error) {
+	select {
+	case <-time.After(m.delay):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	return m.result, m.err
+}
+
+func (m *timedMockResolver) Reverse(ctx context.Context, _ string) (string, error) {
+	return m.Resolve(ctx, "")
+}
+
+func (m *timedMockResolver) Name() string { return m.name }
+func (m *timedMockResolver) Close() error { return nil }
+
+func TestParallelResolver_ParallelBest_WaitsPastNoResolution(t *testing.T) {
+	fast := &timedMockResolver{name: "fast", delay: 5 * time.Millisecond, err: ErrNoResolution}
+	slow := &timedMockResolver{name: "slow", delay: 50 * time.Millisecond, result: "8.8.8.8"}
+
+	p := NewParallelResolver([]Resolver{fast, slow}, false, nil, nil, zap.NewNop())
+
+	ip, err := p.Resolve(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "8.8.8.8", ip)
+}
+
+func TestParallelResolver_ParallelBest_AllNoResolution(t *testing.T) {
+	a := &timedMockResolver{name: "a", err: ErrNoResolution}
+	b := &timedMockResolver{name: "b", delay: 5 * time.Millisecond, err: ErrNoResolution}
+
+	p := NewParallelResolver([]Resolver{a, b}, false, nil, nil, zap.NewNop())
+
+	ip, err := p.Resolve(context.Background(), "example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, ip)
+}
+
+func TestParallelResolver_ParallelBest_AllFail(t *testing.T) {
+	errA := assert.AnError
+	a := &timedMockResolver{name: "a", err: errA}
+	b := &timedMockResolver{name: "b", delay: 5 * time.Millisecond, err: errA}
+
+	p := NewParallelResolver([]Resolver{a, b}, false, nil, nil, zap.NewNop())
+
+	ip, err := p.Resolve(context.Background(), "example.com")
+	require.Error(t, err)
+	assert.Empty(t, ip)
+}
+
+func TestParallelResolver_ParallelFirst_ReturnsFirstReplyEvenIfFailure(t *testing.T) {
+	fast := &timedMockResolver{name: "fast", delay: 5 * time.Millisecond, err: ErrNoResolution}
+	slow := &timedMockResolver{name: "slow", delay: 50 * time.Millisecond, result: "8.8.8.8"}
+
+	p := NewParallelResolver([]Resolver{fast, slow}, true, nil, nil, zap.NewNop())
+
+	ip, err := p.Resolve(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Empty(t, ip)
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+// PolicyRoute maps a set of domain suffixes and/or reverse-lookup CIDRs to
+// the resolver that should handle matching targets.
+type PolicyRoute struct {
+	DomainSuffixes []string
+	CIDRs          []netip.Prefix
+	Resolver       Resolver
+}
+
+// PolicyResolver selects which downstream resolver to consult based on the
+// target being looked up, so that split-horizon zones or internal CIDRs can
+// be routed to a specific resolver instead of a single global chain.
+type PolicyResolver struct {
+	name       string
+	domains    *domainTrie[Resolver]
+	cidrs      *cidrTrie[Resolver]
+	routes     []PolicyRoute
+	defaultRes Resolver
+	logger     *zap.Logger
+}
+
+// NewPolicyResolver builds a PolicyResolver. Targets that match no route fall
+// through to defaultResolver.
+func NewPolicyResolver(routes []PolicyRoute, defaultResolver Resolver, logger *zap.Logger) *PolicyResolver {
+	domains := newDomainTrie[Resolver]()
+	cidrs := newCIDRTrie[Resolver]()
+
+	for _, route := range routes {
+		for _, suffix := range route.DomainSuffixes {
+			domains.Insert(suffix, route.Resolver)
+		}
+		for _, cidr := range route.CIDRs {
+			cidrs.Insert(cidr, route.Resolver)
+		}
+	}
+
+	return &PolicyResolver{
+		name:       "policy",
+		domains:    domains,
+		cidrs:      cidrs,
+		routes:     routes,
+		defaultRes: defaultResolver,
+		logger:     logger,
+	}
+}
+
+// Resolve routes hostname to the resolver registered for its longest
+// matching domain suffix, or to the default chain if nothing matches.
+func (p *PolicyResolver) Resolve(ctx context.Context, hostname string) (string, error) {
+	r, matched := p.domains.LongestMatch(hostname)
+	if !matched {
+		r = p.defaultRes
+	}
+	p.logger.Debug(fmt.Sprintf("Policy routed %s to %s", LogKeyHostname, r.Name()), zap.String(LogKeyHostname, hostname))
+	return r.Resolve(ctx, hostname)
+}
+
+// Reverse routes ip to the resolver registered for the longest matching
+// reverse-zone suffix (e.g. "10.in-addr.arpa") or CIDR, or to the default
+// chain if nothing matches.
+func (p *PolicyResolver) Reverse(ctx context.Context, ip string) (string, error) {
+	if reverseName, err := dns.ReverseAddr(ip); err == nil {
+		if r, matched := p.domains.LongestMatch(strings.TrimSuffix(reverseName, ".")); matched {
+			p.logger.Debug(fmt.Sprintf("Policy routed %s to %s", LogKeyIP, r.Name()), zap.String(LogKeyIP, ip))
+			return r.Reverse(ctx, ip)
+		}
+	}
+
+	if addr, err := netip.ParseAddr(ip); err == nil {
+		if r, matched := p.cidrs.LongestMatch(addr); matched {
+			p.logger.Debug(fmt.Sprintf("Policy routed %s to %s", LogKeyIP, r.Name()), zap.String(LogKeyIP, ip))
+			return r.Reverse(ctx, ip)
+		}
+	}
+
+	return p.defaultRes.Reverse(ctx, ip)
+}
+
+func (p *PolicyResolver) Name() string {
+	return p.name
+}
+
+// Close closes the default resolver and every distinct resolver referenced
+// by a route.
+func (p *PolicyResolver) Close() error {
+	seen := map[Resolver]bool{p.defaultRes: true}
+	errs := []error{p.defaultRes.Close()}
+
+	for _, route := range p.routes {
+		if seen[route.Resolver] {
+			continue
+		}
+		seen[route.Resolver] = true
+		errs = append(errs, route.Resolver.Close())
+	}
+
+	return errors.Join(errs...)
+}
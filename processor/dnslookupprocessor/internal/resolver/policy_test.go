@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type namedMockResolver struct {
+	name          string
+	resolveResult string
+	reverseResult string
+}
+
+func (m *namedMockResolver) Resolve(context.Context, string) (string, error) { return m.resolveResult, nil }
+func (m *namedMockResolver) Reverse(context.Context, string) (string, error) { return m.reverseResult, nil }
+func (m *namedMockResolver) Name() string                                   { return m.name }
+func (m *namedMockResolver) Close() error                                   { return nil }
+
+func TestPolicyResolver_Resolve(t *testing.T) {
+	internal := &namedMockResolver{name: "internal", resolveResult: "10.0.0.1"}
+	defaultResolver := &namedMockResolver{name: "default", resolveResult: "8.8.8.8"}
+
+	p := NewPolicyResolver([]PolicyRoute{
+		{DomainSuffixes: []string{"*.internal.corp"}, Resolver: internal},
+	}, defaultResolver, zap.NewNop())
+
+	ip, err := p.Resolve(context.Background(), "host.internal.corp")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip)
+
+	ip, err = p.Resolve(context.Background(), "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "8.8.8.8", ip)
+}
+
+func TestPolicyResolver_Reverse(t *testing.T) {
+	corpVPN := &namedMockResolver{name: "corp-vpn", reverseResult: "host.corp"}
+	defaultResolver := &namedMockResolver{name: "default", reverseResult: "host.example.com"}
+
+	p := NewPolicyResolver([]PolicyRoute{
+		{CIDRs: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, Resolver: corpVPN},
+	}, defaultResolver, zap.NewNop())
+
+	hostname, err := p.Reverse(context.Background(), "10.1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, "host.corp", hostname)
+
+	hostname, err = p.Reverse(context.Background(), "192.168.1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "host.example.com", hostname)
+}
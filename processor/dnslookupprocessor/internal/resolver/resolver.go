@@ -33,12 +33,14 @@ var (
 // Resolver defines methods for DNS resolution operations
 type Resolver interface {
 	// Resolve performs forward DNS resolution (hostname to IP)
-	// Returns IP addresses as strings or error if resolution fails
-	Resolve(ctx context.Context, hostname string) ([]string, error)
+	// Returns the resolved IP address. If no resolution was found, returns an
+	// empty string with either a nil error or ErrNoResolution
+	Resolve(ctx context.Context, hostname string) (string, error)
 
 	// Reverse performs reverse DNS resolution (IP to hostname)
-	// Returns hostnames as strings or error if resolution fails
-	Reverse(ctx context.Context, ip string) ([]string, error)
+	// Returns the resolved hostname. If no resolution was found, returns an
+	// empty string with either a nil error or ErrNoResolution
+	Reverse(ctx context.Context, ip string) (string, error)
 
 	Name() string
 
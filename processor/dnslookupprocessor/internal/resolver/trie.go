@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// domainTrie indexes values by domain suffix (e.g. "internal.corp" matches
+// "a.b.internal.corp") and returns the value registered for the longest
+// matching suffix.
+type domainTrie[T any] struct {
+	root *domainNode[T]
+}
+
+type domainNode[T any] struct {
+	children map[string]*domainNode[T]
+	value    T
+	hasValue bool
+}
+
+func newDomainTrie[T any]() *domainTrie[T] {
+	return &domainTrie[T]{root: &domainNode[T]{children: map[string]*domainNode[T]{}}}
+}
+
+// Insert registers value for the given suffix. A leading "*." is stripped,
+// so "*.internal.corp" and "internal.corp" are equivalent.
+func (t *domainTrie[T]) Insert(suffix string, value T) {
+	suffix = strings.TrimPrefix(suffix, "*.")
+	labels := splitLabels(suffix)
+
+	node := t.root
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := labels[i]
+		child, ok := node.children[label]
+		if !ok {
+			child = &domainNode[T]{children: map[string]*domainNode[T]{}}
+			node.children[label] = child
+		}
+		node = child
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// LongestMatch returns the value registered for the longest suffix of
+// hostname present in the trie.
+func (t *domainTrie[T]) LongestMatch(hostname string) (T, bool) {
+	labels := splitLabels(hostname)
+
+	node := t.root
+	var (
+		best    T
+		found   bool
+	)
+	for i := len(labels) - 1; i >= 0; i-- {
+		child, ok := node.children[labels[i]]
+		if !ok {
+			break
+		}
+		node = child
+		if node.hasValue {
+			best = node.value
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+func splitLabels(hostname string) []string {
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+	if hostname == "" {
+		return nil
+	}
+	return strings.Split(hostname, ".")
+}
+
+// cidrTrie is a binary trie over IP address bits that returns the value
+// registered for the longest matching prefix containing a given address.
+type cidrTrie[T any] struct {
+	root *cidrNode[T]
+}
+
+type cidrNode[T any] struct {
+	children [2]*cidrNode[T]
+	value    T
+	hasValue bool
+}
+
+func newCIDRTrie[T any]() *cidrTrie[T] {
+	return &cidrTrie[T]{root: &cidrNode[T]{}}
+}
+
+// Insert registers value for the given prefix.
+func (t *cidrTrie[T]) Insert(prefix netip.Prefix, value T) {
+	prefix = prefix.Masked()
+	addr := prefix.Addr()
+	bits := prefix.Bits()
+
+	node := t.root
+	for i := 0; i < bits; i++ {
+		bit := addrBit(addr, i)
+		if node.children[bit] == nil {
+			node.children[bit] = &cidrNode[T]{}
+		}
+		node = node.children[bit]
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// LongestMatch returns the value registered for the longest prefix that
+// contains addr.
+func (t *cidrTrie[T]) LongestMatch(addr netip.Addr) (T, bool) {
+	if addr.Is4In6() {
+		addr = addr.Unmap()
+	}
+
+	node := t.root
+	var (
+		best  T
+		found bool
+	)
+	if node.hasValue {
+		best, found = node.value, true
+	}
+
+	totalBits := addr.BitLen()
+	for i := 0; i < totalBits; i++ {
+		child := node.children[addrBit(addr, i)]
+		if child == nil {
+			break
+		}
+		node = child
+		if node.hasValue {
+			best, found = node.value, true
+		}
+	}
+
+	return best, found
+}
+
+func addrBit(addr netip.Addr, i int) int {
+	b := addr.As16()
+	byteIdx := i / 8
+	bitIdx := 7 - (i % 8)
+	if addr.Is4() {
+		b4 := addr.As4()
+		return int((b4[byteIdx] >> bitIdx) & 1)
+	}
+	return int((b[byteIdx] >> bitIdx) & 1)
+}
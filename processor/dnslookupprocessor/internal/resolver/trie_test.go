@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainTrie_LongestMatch(t *testing.T) {
+	trie := newDomainTrie[string]()
+	trie.Insert("corp", "global")
+	trie.Insert("internal.corp", "split-horizon")
+	trie.Insert("*.eng.internal.corp", "eng")
+
+	tests := []struct {
+		hostname string
+		want     string
+		wantOK   bool
+	}{
+		{"www.corp", "global", true},
+		{"a.internal.corp", "split-horizon", true},
+		{"host.eng.internal.corp", "eng", true},
+		{"example.com", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := trie.LongestMatch(tt.hostname)
+		assert.Equal(t, tt.wantOK, ok, tt.hostname)
+		if tt.wantOK {
+			assert.Equal(t, tt.want, got, tt.hostname)
+		}
+	}
+}
+
+func TestCIDRTrie_LongestMatch(t *testing.T) {
+	trie := newCIDRTrie[string]()
+	trie.Insert(netip.MustParsePrefix("10.0.0.0/8"), "corp")
+	trie.Insert(netip.MustParsePrefix("10.1.0.0/16"), "corp-vpn")
+
+	tests := []struct {
+		addr   string
+		want   string
+		wantOK bool
+	}{
+		{"10.1.2.3", "corp-vpn", true},
+		{"10.2.2.3", "corp", true},
+		{"192.168.1.1", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := trie.LongestMatch(netip.MustParseAddr(tt.addr))
+		assert.Equal(t, tt.wantOK, ok, tt.addr)
+		if tt.wantOK {
+			assert.Equal(t, tt.want, got, tt.addr)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// telemetryBuilder holds the processor's self-reported metrics.
+type telemetryBuilder struct {
+	meter metric.Meter
+
+	blockedLookups metric.Int64Counter
+	asyncDropped   metric.Int64Counter
+}
+
+func newTelemetryBuilder(settings component.TelemetrySettings) (*telemetryBuilder, error) {
+	meter := settings.MeterProvider.Meter("github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor")
+
+	blockedLookups, err := meter.Int64Counter(
+		"dnslookup_blocked_lookups_total",
+		metric.WithDescription("Number of DNS lookups skipped because the target matched a deny/allow list"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dnslookup_blocked_lookups_total counter: %w", err)
+	}
+
+	asyncDropped, err := meter.Int64Counter(
+		"dnslookup_async_dropped_total",
+		metric.WithDescription("Number of async DNS lookups dropped because the queue was full"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dnslookup_async_dropped_total counter: %w", err)
+	}
+
+	return &telemetryBuilder{meter: meter, blockedLookups: blockedLookups, asyncDropped: asyncDropped}, nil
+}
+
+// recordBlockedLookup increments dnslookup_blocked_lookups_total for a
+// lookup skipped because it matched list, for the given reason (e.g.
+// "deny_hostnames", "deny_ips").
+func (t *telemetryBuilder) recordBlockedLookup(ctx context.Context, reason, list string) {
+	t.blockedLookups.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("reason", reason),
+		attribute.String("list", list),
+	))
+}
+
+// recordAsyncDropped increments dnslookup_async_dropped_total for a lookup of
+// the given kind ("resolve" or "reverse") dropped because the async queue
+// was full.
+func (t *telemetryBuilder) recordAsyncDropped(ctx context.Context, kind string) {
+	t.asyncDropped.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+}
+
+// registerAsyncQueueDepth registers dnslookup_async_queue_depth as an
+// observable gauge backed by depthFn, called once per async pool since the
+// metric only applies when async mode is enabled.
+func (t *telemetryBuilder) registerAsyncQueueDepth(depthFn func() int64) error {
+	_, err := t.meter.Int64ObservableGauge(
+		"dnslookup_async_queue_depth",
+		metric.WithDescription("Current number of queued async DNS lookups"),
+		metric.WithUnit("1"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(depthFn())
+			return nil
+		}),
+	)
+	return err
+}